@@ -0,0 +1,137 @@
+// Package extractor turns the raw HTML of an article page into its body
+// text. Historically this logic lived in the nytimes package and only knew
+// how to find NYTimes' articleBody node; it now lives here as a registry so
+// other publishers (WaPo, Reddit, Cloudflare Stream pages, ...) can plug in
+// their own extraction strategy without touching callers.
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// Extractor knows how to pull the body text out of a single site's article
+// DOM. Match reports whether this Extractor handles the given host; Extract
+// is only called once a match has been found. rawURL is passed alongside
+// the parsed doc so an Extractor can derive URL-based context (e.g. NYTimes
+// deriving its section from the path to pick a selector override). ctx is
+// the same context.Context passed to ExtractText, so a caller can scope
+// per-call configuration (e.g. NYTimes' selector overrides) through it
+// instead of through package-level state.
+type Extractor interface {
+	Match(host string) bool
+	Extract(ctx context.Context, doc *html.Node, rawURL string) (string, error)
+}
+
+var registry []Extractor
+
+// Register adds e to the set of site-specific extractors consulted by
+// ExtractText. Site packages call this from an init() function, the same
+// way database/sql drivers register themselves.
+func Register(e Extractor) {
+	registry = append(registry, e)
+}
+
+// maxFetchAttempts bounds the retry-with-backoff loop in fetchHTML.
+const maxFetchAttempts = 3
+
+// ExtractText fetches rawURL and returns its article body text, dispatching
+// to whichever registered Extractor matches the URL's host, and falling
+// back to a generic readability-style extractor when none does. ctx governs
+// the HTTP request(s), including retries on transient errors.
+func ExtractText(ctx context.Context, rawURL string) (string, error) {
+	bodyString, err := fetchHTML(ctx, rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	doc, err := html.Parse(strings.NewReader(bodyString))
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	return lookup(u.Host).Extract(ctx, doc, rawURL)
+}
+
+// lookup returns the first registered Extractor matching host, or the
+// generic fallback if none do.
+func lookup(host string) Extractor {
+	for _, e := range registry {
+		if e.Match(host) {
+			return e
+		}
+	}
+
+	return genericExtractor{}
+}
+
+// fetchHTML downloads the raw HTML at rawURL, retrying with exponential
+// backoff on transient errors (network errors and 5xx/429 responses) up to
+// maxFetchAttempts times.
+func fetchHTML(ctx context.Context, rawURL string) (string, error) {
+	var lastErr error
+
+	for attempt := 0; attempt < maxFetchAttempts; attempt++ {
+		if attempt > 0 {
+			backoff := time.Duration(1<<uint(attempt-1)) * time.Second
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		body, retriable, err := doFetch(ctx, rawURL)
+		if err == nil {
+			return body, nil
+		}
+
+		lastErr = err
+		if !retriable {
+			return "", err
+		}
+	}
+
+	return "", lastErr
+}
+
+// doFetch performs a single fetch attempt. retriable reports whether the
+// caller should retry after a transient failure.
+func doFetch(ctx context.Context, rawURL string) (body string, retriable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", false, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+		return "", true, fmt.Errorf("extractor: transient status %d fetching %s", resp.StatusCode, rawURL)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", false, fmt.Errorf("extractor: unexpected status %d fetching %s", resp.StatusCode, rawURL)
+	}
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", true, err
+	}
+
+	return string(bytes), false, nil
+}
@@ -0,0 +1,69 @@
+package extractor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFetchHTMLRetriesTransientErrors(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	body, err := fetchHTML(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("fetchHTML() error = %v", err)
+	}
+	if body != "ok" {
+		t.Errorf("fetchHTML() = %q, want %q", body, "ok")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("server saw %d attempts, want 3", got)
+	}
+}
+
+func TestFetchHTMLGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := fetchHTML(context.Background(), server.URL)
+	if err == nil {
+		t.Fatal("fetchHTML() error = nil, want an error after exhausting retries")
+	}
+	if got := atomic.LoadInt32(&attempts); got != maxFetchAttempts {
+		t.Errorf("server saw %d attempts, want %d", got, maxFetchAttempts)
+	}
+}
+
+func TestFetchHTMLDoesNotRetryNonTransientErrors(t *testing.T) {
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := fetchHTML(context.Background(), server.URL)
+	if err == nil {
+		t.Fatal("fetchHTML() error = nil, want an error for a 404")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Errorf("server saw %d attempts, want 1 (no retry on a non-transient status)", got)
+	}
+}
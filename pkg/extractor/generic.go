@@ -0,0 +1,72 @@
+package extractor
+
+import (
+	"context"
+
+	"golang.org/x/net/html"
+)
+
+// genericExtractor is the fallback used when no site-specific Extractor
+// matches. It approximates readability-style extraction: find the
+// container (div/article/section) whose direct <p> children hold the most
+// text, and return that text. This is deliberately simple rather than a
+// full Readability port.
+type genericExtractor struct{}
+
+func (genericExtractor) Match(string) bool { return true }
+
+func (genericExtractor) Extract(_ context.Context, doc *html.Node, rawURL string) (string, error) {
+	var best string
+	var bestLen int
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "div", "article", "section":
+				if text := directParagraphText(n); len(text) > bestLen {
+					best = text
+					bestLen = len(text)
+				}
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return best, nil
+}
+
+// directParagraphText concatenates the text of every <p> that is a direct
+// child of n.
+func directParagraphText(n *html.Node) string {
+	var out string
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "p" {
+			out += paragraphText(c) + "\n"
+		}
+	}
+
+	return out
+}
+
+func paragraphText(p *html.Node) string {
+	var out string
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			out += n.Data
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(p)
+
+	return out
+}
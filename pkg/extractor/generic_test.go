@@ -0,0 +1,44 @@
+package extractor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestGenericExtractorPicksLongestContainer(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body>
+		<div><p>Short.</p></div>
+		<article><p>This is the real article body.</p><p>It has several sentences.</p></article>
+	</body></html>`))
+	if err != nil {
+		t.Fatalf("html.Parse() error = %v", err)
+	}
+
+	text, err := (genericExtractor{}).Extract(context.Background(), doc, "https://example.com/a")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	want := "This is the real article body.\nIt has several sentences.\n"
+	if text != want {
+		t.Errorf("Extract() = %q, want %q", text, want)
+	}
+}
+
+func TestGenericExtractorNoParagraphs(t *testing.T) {
+	doc, err := html.Parse(strings.NewReader(`<html><body><div>No paragraphs here.</div></body></html>`))
+	if err != nil {
+		t.Fatalf("html.Parse() error = %v", err)
+	}
+
+	text, err := (genericExtractor{}).Extract(context.Background(), doc, "https://example.com/a")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if text != "" {
+		t.Errorf("Extract() = %q, want empty string when there are no <p> children", text)
+	}
+}
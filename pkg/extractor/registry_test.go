@@ -0,0 +1,39 @@
+package extractor
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+type stubExtractor struct {
+	host string
+	text string
+}
+
+func (s stubExtractor) Match(host string) bool { return host == s.host }
+
+func (s stubExtractor) Extract(context.Context, *html.Node, string) (string, error) {
+	return s.text, nil
+}
+
+func TestLookupDispatchesToMatchingExtractor(t *testing.T) {
+	Register(stubExtractor{host: "stub.example", text: "stub text"})
+
+	e := lookup("stub.example")
+	text, err := e.Extract(context.Background(), nil, "https://stub.example/a")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if text != "stub text" {
+		t.Errorf("lookup(%q).Extract() = %q, want %q", "stub.example", text, "stub text")
+	}
+}
+
+func TestLookupFallsBackToGeneric(t *testing.T) {
+	e := lookup("unregistered.example")
+	if _, ok := e.(genericExtractor); !ok {
+		t.Errorf("lookup() for an unregistered host = %T, want genericExtractor", e)
+	}
+}
@@ -0,0 +1,58 @@
+// Package cloudflarestream registers an extractor.Extractor for Cloudflare
+// Stream asset pages (cloudflarestream.com / videodelivery.net). These are
+// video players, not articles, so there's no paragraph body to walk; the
+// best available text is the page's meta description.
+package cloudflarestream
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vitsensei/infogrid/pkg/extractor"
+	"golang.org/x/net/html"
+)
+
+func init() {
+	extractor.Register(siteExtractor{})
+}
+
+type siteExtractor struct{}
+
+func (siteExtractor) Match(host string) bool {
+	return strings.HasSuffix(host, "cloudflarestream.com") || strings.HasSuffix(host, "videodelivery.net")
+}
+
+func (siteExtractor) Extract(_ context.Context, doc *html.Node, rawURL string) (string, error) {
+	var description string
+
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if description != "" {
+			return
+		}
+
+		if n.Type == html.ElementNode && n.Data == "meta" {
+			var isDescription bool
+			for _, a := range n.Attr {
+				if (a.Key == "property" && a.Val == "og:description") ||
+					(a.Key == "name" && a.Val == "description") {
+					isDescription = true
+				}
+			}
+			if isDescription {
+				for _, a := range n.Attr {
+					if a.Key == "content" {
+						description = a.Val
+					}
+				}
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return description, nil
+}
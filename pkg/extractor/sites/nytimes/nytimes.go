@@ -0,0 +1,120 @@
+// Package nytimes registers an extractor.Extractor for nytimes.com. Body
+// paragraphs are located with a CSS selector (via goquery) instead of a
+// hand-rolled DOM walk, so the locator is just data: a string that can be
+// swapped per section with WithSelectors.
+package nytimes
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/vitsensei/infogrid/pkg/extractor"
+	"golang.org/x/net/html"
+)
+
+// defaultSelector matches NYTimes' standard article markup, old and new:
+// the articleBody node (by name attribute) wrapping <p> paragraphs.
+const defaultSelector = `[name="articleBody"] p, section[name="articleBody"] p`
+
+func init() {
+	extractor.Register(siteExtractor{})
+}
+
+type selectorsKey struct{}
+
+// WithSelectors returns a copy of ctx carrying per-section selector
+// overrides (keyed by the section sectionFromURL derives from the article
+// URL) for extractor.ExtractText to use in place of defaultSelector. The
+// overrides live on ctx rather than in package state, so two concurrent
+// callers - e.g. two nytimes.API instances - with different overrides for
+// the same section don't stomp each other.
+func WithSelectors(ctx context.Context, selectors map[string]string) context.Context {
+	return context.WithValue(ctx, selectorsKey{}, selectors)
+}
+
+func selectorFor(ctx context.Context, rawURL string) string {
+	if selectors, ok := ctx.Value(selectorsKey{}).(map[string]string); ok {
+		if selector, ok := selectors[sectionFromURL(rawURL)]; ok {
+			return selector
+		}
+	}
+	return defaultSelector
+}
+
+// sectionFromURL returns the first non-numeric path segment of rawURL,
+// which for NYTimes' /YYYY/MM/DD/section/slug.html URLs is the section.
+func sectionFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	for _, segment := range strings.Split(strings.Trim(u.Path, "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(segment); err == nil {
+			continue
+		}
+		return segment
+	}
+
+	return ""
+}
+
+type siteExtractor struct{}
+
+func (siteExtractor) Match(host string) bool {
+	return strings.HasSuffix(host, "nytimes.com")
+}
+
+func (siteExtractor) Extract(ctx context.Context, doc *html.Node, rawURL string) (string, error) {
+	gdoc := goquery.NewDocumentFromNode(doc)
+
+	var paragraphs []string
+	gdoc.Find(selectorFor(ctx, rawURL)).Each(func(_ int, s *goquery.Selection) {
+		paragraphs = append(paragraphs, s.Text())
+	})
+	if len(paragraphs) > 0 {
+		return strings.Join(paragraphs, "\n"), nil
+	}
+
+	// NYTimes loves interactive articles (and they are amazing!), which
+	// rarely have an articleBody node. Fall back to the og:description
+	// meta tag, then to a NewsArticle JSON-LD payload, before giving up.
+	if description, ok := gdoc.Find(`meta[property="og:description"]`).Attr("content"); ok && description != "" {
+		return description, nil
+	}
+
+	return newsArticleBodyFromLDJSON(gdoc), nil
+}
+
+// newsArticleBodyFromLDJSON scans embedded application/ld+json payloads for
+// a NewsArticle's articleBody, falling back to its description.
+func newsArticleBodyFromLDJSON(gdoc *goquery.Document) string {
+	var body string
+
+	gdoc.Find(`script[type="application/ld+json"]`).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		var payload map[string]interface{}
+		if err := json.Unmarshal([]byte(s.Text()), &payload); err != nil {
+			return true
+		}
+
+		if text, ok := payload["articleBody"].(string); ok && text != "" {
+			body = text
+			return false
+		}
+		if text, ok := payload["description"].(string); ok && text != "" {
+			body = text
+			return false
+		}
+
+		return true
+	})
+
+	return body
+}
@@ -0,0 +1,103 @@
+package nytimes
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestSectionFromURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.nytimes.com/2024/01/02/business/some-slug.html", "business"},
+		{"https://www.nytimes.com/section/world", "section"},
+		{"not a url %zz", ""},
+	}
+
+	for _, tt := range tests {
+		if got := sectionFromURL(tt.url); got != tt.want {
+			t.Errorf("sectionFromURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func parseDoc(t *testing.T, body string) *html.Node {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("html.Parse() error = %v", err)
+	}
+	return doc
+}
+
+func TestExtractPrefersArticleBody(t *testing.T) {
+	doc := parseDoc(t, `<html><body><section name="articleBody"><p>Hello.</p><p>World.</p></section>
+		<meta property="og:description" content="fallback"/></body></html>`)
+
+	text, err := (siteExtractor{}).Extract(context.Background(), doc, "https://www.nytimes.com/2024/01/02/world/a.html")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if text != "Hello.\nWorld." {
+		t.Errorf("Extract() = %q, want %q", text, "Hello.\nWorld.")
+	}
+}
+
+func TestExtractFallsBackToOGDescription(t *testing.T) {
+	doc := parseDoc(t, `<html><head><meta property="og:description" content="the summary"/></head><body></body></html>`)
+
+	text, err := (siteExtractor{}).Extract(context.Background(), doc, "https://www.nytimes.com/interactive/2024/01/02/world/a.html")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if text != "the summary" {
+		t.Errorf("Extract() = %q, want %q", text, "the summary")
+	}
+}
+
+func TestExtractFallsBackToLDJSON(t *testing.T) {
+	doc := parseDoc(t, `<html><head>
+		<script type="application/ld+json">{"@type":"NewsArticle","articleBody":"the body from ld+json"}</script>
+	</head><body></body></html>`)
+
+	text, err := (siteExtractor{}).Extract(context.Background(), doc, "https://www.nytimes.com/interactive/2024/01/02/world/a.html")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if text != "the body from ld+json" {
+		t.Errorf("Extract() = %q, want %q", text, "the body from ld+json")
+	}
+}
+
+func TestWithSelectorsOverridesDefault(t *testing.T) {
+	ctx := WithSelectors(context.Background(), map[string]string{"business": `[data-testid="custom"] p`})
+
+	doc := parseDoc(t, `<html><body><div data-testid="custom"><p>Custom body.</p></div></body></html>`)
+
+	text, err := (siteExtractor{}).Extract(ctx, doc, "https://www.nytimes.com/2024/01/02/business/a.html")
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if text != "Custom body." {
+		t.Errorf("Extract() = %q, want %q", text, "Custom body.")
+	}
+}
+
+func TestWithSelectorsScopedPerContext(t *testing.T) {
+	ctxA := WithSelectors(context.Background(), map[string]string{"business": `[data-testid="a"] p`})
+	ctxB := WithSelectors(context.Background(), map[string]string{"business": `[data-testid="b"] p`})
+
+	if got, want := selectorFor(ctxA, "https://www.nytimes.com/2024/01/02/business/a.html"), `[data-testid="a"] p`; got != want {
+		t.Errorf("selectorFor(ctxA, ...) = %q, want %q", got, want)
+	}
+	if got, want := selectorFor(ctxB, "https://www.nytimes.com/2024/01/02/business/a.html"), `[data-testid="b"] p`; got != want {
+		t.Errorf("selectorFor(ctxB, ...) = %q, want %q", got, want)
+	}
+	if got := selectorFor(context.Background(), "https://www.nytimes.com/2024/01/02/business/a.html"); got != defaultSelector {
+		t.Errorf("selectorFor(no overrides, ...) = %q, want default %q", got, defaultSelector)
+	}
+}
@@ -0,0 +1,105 @@
+// Package reddit registers an extractor.Extractor for reddit.com. Reddit
+// doesn't render post bodies as plain <p> markup; instead the page embeds
+// the post data as JSON in a <script id="data"> tag (window.___r = {...}).
+// This extractor pulls that payload out and walks it for the longest
+// "selftext"/"body" field, which is the actual post text.
+package reddit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/vitsensei/infogrid/pkg/extractor"
+	"golang.org/x/net/html"
+)
+
+func init() {
+	extractor.Register(siteExtractor{})
+}
+
+type siteExtractor struct{}
+
+func (siteExtractor) Match(host string) bool {
+	return strings.HasSuffix(host, "reddit.com")
+}
+
+func (siteExtractor) Extract(_ context.Context, doc *html.Node, rawURL string) (string, error) {
+	raw := dataScriptContents(doc)
+	if raw == "" {
+		return "", nil
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		return "", nil
+	}
+
+	return longestTextField(payload, "selftext", "body"), nil
+}
+
+// dataScriptContents returns the JSON object literal assigned to
+// window.___r inside <script id="data">, or "" if not found.
+func dataScriptContents(doc *html.Node) string {
+	var script *html.Node
+
+	var find func(*html.Node)
+	find = func(n *html.Node) {
+		if script != nil {
+			return
+		}
+		if n.Type == html.ElementNode && n.Data == "script" {
+			for _, a := range n.Attr {
+				if a.Key == "id" && a.Val == "data" {
+					script = n
+					return
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			find(c)
+		}
+	}
+	find(doc)
+
+	if script == nil || script.FirstChild == nil {
+		return ""
+	}
+
+	content := script.FirstChild.Data
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start == -1 || end == -1 || end < start {
+		return ""
+	}
+
+	return content[start : end+1]
+}
+
+// longestTextField recursively walks a decoded JSON value and returns the
+// longest string found under any of the given field names.
+func longestTextField(v interface{}, fields ...string) string {
+	var best string
+
+	var walk func(interface{})
+	walk = func(v interface{}) {
+		switch val := v.(type) {
+		case map[string]interface{}:
+			for _, field := range fields {
+				if s, ok := val[field].(string); ok && len(s) > len(best) {
+					best = s
+				}
+			}
+			for _, child := range val {
+				walk(child)
+			}
+		case []interface{}:
+			for _, child := range val {
+				walk(child)
+			}
+		}
+	}
+	walk(v)
+
+	return best
+}
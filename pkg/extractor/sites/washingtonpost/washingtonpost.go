@@ -0,0 +1,70 @@
+// Package washingtonpost registers an extractor.Extractor for
+// washingtonpost.com, keyed off the data-qa="article-body" container.
+package washingtonpost
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vitsensei/infogrid/pkg/extractor"
+	"golang.org/x/net/html"
+)
+
+func init() {
+	extractor.Register(siteExtractor{})
+}
+
+type siteExtractor struct{}
+
+func (siteExtractor) Match(host string) bool {
+	return strings.HasSuffix(host, "washingtonpost.com")
+}
+
+func (siteExtractor) Extract(_ context.Context, doc *html.Node, rawURL string) (string, error) {
+	var articleBody *html.Node
+
+	var find func(*html.Node)
+	find = func(n *html.Node) {
+		if articleBody != nil {
+			return
+		}
+
+		if hasAttr(n, "data-qa", "article-body") {
+			articleBody = n
+			return
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			find(c)
+		}
+	}
+	find(doc)
+
+	if articleBody == nil {
+		return "", nil
+	}
+
+	var text string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode && n.Parent != nil && n.Parent.Data == "p" {
+			text += n.Data + "\n"
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(articleBody)
+
+	return text, nil
+}
+
+func hasAttr(n *html.Node, key, val string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key && a.Val == val {
+			return true
+		}
+	}
+	return false
+}
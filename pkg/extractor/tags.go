@@ -0,0 +1,49 @@
+package extractor
+
+import (
+	"sort"
+	"strings"
+)
+
+// stopWords are excluded when ranking candidate tags; this is not meant to
+// be exhaustive, just enough to keep common filler words out of the top N.
+var stopWords = map[string]bool{
+	"the": true, "a": true, "an": true, "and": true, "or": true, "but": true,
+	"is": true, "are": true, "was": true, "were": true, "be": true, "been": true,
+	"to": true, "of": true, "in": true, "on": true, "for": true, "with": true,
+	"as": true, "by": true, "at": true, "from": true, "that": true, "this": true,
+	"it": true, "its": true, "he": true, "she": true, "they": true, "we": true,
+	"said": true, "has": true, "have": true, "had": true, "will": true,
+}
+
+// ExtractTags returns the n most frequent non-stopword tokens in text,
+// lower-cased, as a rough stand-in for proper keyword/entity extraction.
+func ExtractTags(text string, n int) ([]string, error) {
+	counts := map[string]int{}
+
+	for _, word := range strings.Fields(text) {
+		word = strings.ToLower(strings.Trim(word, ".,!?\"'()[]{}:;"))
+		if word == "" || stopWords[word] {
+			continue
+		}
+		counts[word]++
+	}
+
+	candidates := make([]string, 0, len(counts))
+	for word := range counts {
+		candidates = append(candidates, word)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if counts[candidates[i]] != counts[candidates[j]] {
+			return counts[candidates[i]] > counts[candidates[j]]
+		}
+		return candidates[i] < candidates[j]
+	})
+
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+
+	return candidates, nil
+}
@@ -0,0 +1,40 @@
+package extractor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractTagsRanksByFrequency(t *testing.T) {
+	text := "the cat sat on the mat. The cat was happy."
+
+	tags, err := ExtractTags(text, 2)
+	if err != nil {
+		t.Fatalf("ExtractTags() error = %v", err)
+	}
+
+	want := []string{"cat", "happy"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("ExtractTags() = %v, want %v", tags, want)
+	}
+}
+
+func TestExtractTagsExcludesStopWords(t *testing.T) {
+	tags, err := ExtractTags("the a an and or but is", 5)
+	if err != nil {
+		t.Fatalf("ExtractTags() error = %v", err)
+	}
+	if len(tags) != 0 {
+		t.Errorf("ExtractTags() = %v, want no tags since input is all stop words", tags)
+	}
+}
+
+func TestExtractTagsCapsAtN(t *testing.T) {
+	tags, err := ExtractTags("alpha beta gamma delta", 2)
+	if err != nil {
+		t.Fatalf("ExtractTags() error = %v", err)
+	}
+	if len(tags) != 2 {
+		t.Errorf("ExtractTags() returned %d tags, want 2", len(tags))
+	}
+}
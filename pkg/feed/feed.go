@@ -0,0 +1,78 @@
+// Package feed provides a provider-agnostic way of pulling syndicated
+// content (RSS/Atom) into a common shape so callers aren't limited to
+// providers that expose a JSON/API-key endpoint, such as NYTimes' Top
+// Stories API.
+package feed
+
+import (
+	"html"
+	"time"
+
+	"github.com/mmcdole/gofeed"
+)
+
+// Item is the normalized representation of a single feed entry, regardless
+// of which Source produced it.
+type Item struct {
+	URL         string
+	Title       string
+	Summary     string
+	Section     string
+	DateCreated string
+}
+
+// Source fetches the items published at a single feed URL (typically one
+// per section, e.g. https://rss.nytimes.com/services/xml/rss/nyt/HomePage.xml).
+// Implementing this interface is how a non-NYTimes provider can plug into
+// the same ingestion path.
+type Source interface {
+	FetchItems(feedURL string) ([]Item, error)
+}
+
+// RSSSource fetches and parses RSS/Atom feeds using gofeed.
+type RSSSource struct {
+	parser *gofeed.Parser
+}
+
+// NewRSSSource returns a Source backed by a gofeed parser.
+func NewRSSSource() *RSSSource {
+	return &RSSSource{
+		parser: gofeed.NewParser(),
+	}
+}
+
+// FetchItems downloads and parses the feed at feedURL, unescaping HTML
+// entities in the title/summary and normalizing the published date to
+// time.RFC3339 so it can be dropped straight into Article.DateCreated.
+func (s *RSSSource) FetchItems(feedURL string) ([]Item, error) {
+	parsed, err := s.parser.ParseURL(feedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, len(parsed.Items))
+	for _, entry := range parsed.Items {
+		items = append(items, Item{
+			URL:         entry.Link,
+			Title:       html.UnescapeString(entry.Title),
+			Summary:     html.UnescapeString(entry.Description),
+			DateCreated: normalizeDate(entry),
+		})
+	}
+
+	return items, nil
+}
+
+// normalizeDate prefers the parsed published time gofeed already extracted,
+// falling back to the updated time, and finally to the zero time formatted
+// as RFC3339 so DateCreated is never left in a provider-specific layout.
+func normalizeDate(entry *gofeed.Item) string {
+	switch {
+	case entry.PublishedParsed != nil:
+		return entry.PublishedParsed.UTC().Format(time.RFC3339)
+	case entry.UpdatedParsed != nil:
+		return entry.UpdatedParsed.UTC().Format(time.RFC3339)
+	default:
+		return time.Time{}.UTC().Format(time.RFC3339)
+	}
+}
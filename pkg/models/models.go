@@ -0,0 +1,22 @@
+// Package models holds the shared, provider-agnostic article shape that
+// consumers (publishers, controllers, ...) program against, so they don't
+// need to depend on a specific provider package like pkg/nytimes.
+package models
+
+// ArticleInterface is the set of getters/setters a consumer needs to read
+// and summarise an article regardless of which provider produced it.
+// pkg/nytimes.Article implements this.
+type ArticleInterface interface {
+	GetURL() string
+	GetTitle() string
+	GetSection() string
+	GetDateCreated() string
+
+	SetText(string)
+	GetText() string
+
+	SetSummarised(string)
+	GetSummarised() string
+
+	GetTags() []string
+}
@@ -1,22 +1,53 @@
 package nytimes
 
 import (
+	"context"
 	"encoding/json"
 	"github.com/vitsensei/infogrid/pkg/extractor"
+	_ "github.com/vitsensei/infogrid/pkg/extractor/sites/cloudflarestream"
+	nytimesextractor "github.com/vitsensei/infogrid/pkg/extractor/sites/nytimes"
+	_ "github.com/vitsensei/infogrid/pkg/extractor/sites/reddit"
+	_ "github.com/vitsensei/infogrid/pkg/extractor/sites/washingtonpost"
+	"github.com/vitsensei/infogrid/pkg/feed"
 	"github.com/vitsensei/infogrid/pkg/models"
-	"golang.org/x/net/html"
+	"github.com/vitsensei/infogrid/pkg/seenstore"
+	"golang.org/x/sync/errgroup"
 	"io/ioutil"
 	"net/http"
 	"os"
-	"strings"
-	"sync"
 	"time"
 )
 
+// defaultConcurrency bounds how many articles are extracted at once when
+// the caller hasn't set one via API.WithConcurrency. NYTimes has been known
+// to 429 clients that hammer it with dozens of simultaneous requests.
+const defaultConcurrency = 4
+
 var (
 	apiKey             = os.Getenv("NYTIMES_KEY")
 	partialTopStoryURL = "https://api.nytimes.com/svc/topstories/v2/home.json?api-key="
-	wg                 sync.WaitGroup
+
+	// sectionFeedURLs maps an allowed section to its NYTimes RSS feed,
+	// used when the API is configured with SourceRSS.
+	sectionFeedURLs = map[string]string{
+		"business":   "https://rss.nytimes.com/services/xml/rss/nyt/Business.xml",
+		"politics":   "https://rss.nytimes.com/services/xml/rss/nyt/Politics.xml",
+		"technology": "https://rss.nytimes.com/services/xml/rss/nyt/Technology.xml",
+		"us":         "https://rss.nytimes.com/services/xml/rss/nyt/US.xml",
+		"world":      "https://rss.nytimes.com/services/xml/rss/nyt/World.xml",
+	}
+)
+
+// SourceType controls where API.GenerateArticles pulls its stories from.
+type SourceType int
+
+const (
+	// SourceAPIKey uses the API-key-gated NYTimes Top Stories JSON endpoint.
+	// This is the default, matching the original behaviour.
+	SourceAPIKey SourceType = iota
+	// SourceRSS uses the public, key-free NYTimes RSS/Atom feeds instead,
+	// one per allowed section.
+	SourceRSS
 )
 
 /*
@@ -88,17 +119,83 @@ type TopStories struct {
 	Articles []Article `json:"results"`
 }
 
+// Publisher is an optional sink that GenerateArticles feeds every newly
+// extracted article through, e.g. to toot it to Mastodon. It's defined
+// here rather than depending on pkg/publisher/mastodon directly so that
+// package (and any future publisher) stays decoupled from nytimes.
+type Publisher interface {
+	Publish(article models.ArticleInterface) error
+}
+
 // The API for other package to interact with
 type API struct {
 	url             string
 	allowedSections []string
+	sourceType      SourceType
+	feedSource      feed.Source
+	publisher       Publisher
+	seenStore       seenstore.SeenStore
+	concurrency     int
+	selectors       map[string]string
 	TopStories      TopStories `json:"body"`
 }
 
 func NewAPI() *API {
 	return &API{
 		allowedSections: []string{"business", "politics", "technology", "us", "world"},
+		sourceType:      SourceAPIKey,
+		concurrency:     defaultConcurrency,
+	}
+}
+
+// WithConcurrency bounds how many articles GenerateArticles extracts at
+// once. n <= 0 is ignored (the default is kept).
+func (a *API) WithConcurrency(n int) *API {
+	if n > 0 {
+		a.concurrency = n
+	}
+	return a
+}
+
+// WithSourceType switches where GenerateArticles pulls stories from. Passing
+// SourceRSS lets a caller without an NYTIMES_KEY still populate
+// TopStories.Articles, via sectionFeedURLs.
+func (a *API) WithSourceType(t SourceType) *API {
+	a.sourceType = t
+	if t == SourceRSS && a.feedSource == nil {
+		a.feedSource = feed.NewRSSSource()
+	}
+	return a
+}
+
+// WithSeenStore wires in a persistent dedup store: articles whose
+// URL+published-date key is already marked seen are skipped entirely
+// before extraction, and GetArticles/GenerateArticles only surface the
+// delta of newly-seen articles on each call.
+func (a *API) WithSeenStore(store seenstore.SeenStore) *API {
+	a.seenStore = store
+	return a
+}
+
+// WithPublisher wires an optional sink into GenerateArticles: every article
+// that comes out with non-empty text is handed to pub.Publish after
+// extraction, e.g. to toot it via pkg/publisher/mastodon.
+func (a *API) WithPublisher(pub Publisher) *API {
+	a.publisher = pub
+	return a
+}
+
+// WithSelector overrides the CSS selector used to locate an article's body
+// paragraphs for the given section (e.g. "business"), in place of the
+// nytimes extractor's default
+// `[name="articleBody"] p, section[name="articleBody"] p` selector. The
+// override is scoped to this API instance, not shared process-wide.
+func (a *API) WithSelector(section, selector string) *API {
+	if a.selectors == nil {
+		a.selectors = map[string]string{}
 	}
+	a.selectors[section] = selector
+	return a
 }
 
 // Used in controller/article to filter out the "non-news" sections
@@ -122,74 +219,53 @@ func (a *API) generateURL() {
 	a.url = partialTopStoryURL + apiKey
 }
 
-// Used in ExtractText to detect ArticleBody node
-func isArticleBody(n html.Node) bool {
-	for _, a := range n.Attr {
-		if a.Key == "name" && a.Val == "articleBody" {
-			return true
-		}
-	}
-
-	return false
-}
-
-// Given a URL, the text will be extracted (if exist)
-func ExtractText(url string) (string, error) {
-	var paragraph string
-
-	bodyString, err := extractor.ExtractTextFromURL(url)
+// fetchFromFeeds populates TopStories.Articles from sectionFeedURLs using
+// a.feedSource, as an alternative to the API-key JSON endpoint.
+func (a *API) fetchFromFeeds() error {
+	var articles []Article
 
-	doc, err := html.Parse(strings.NewReader(bodyString))
-
-	if err != nil {
-		return "", err
-	}
-
-	var articleBodyNode *html.Node
-
-	// All the actual writing is in Article Body node. Find this node
-	// and extract text from it to avoid extracting rubbish
-	var findArticleBodyNode func(*html.Node)
-	findArticleBodyNode = func(n *html.Node) {
-		if isArticleBody(*n) {
-			articleBodyNode = n
-			return
+	for _, section := range a.allowedSections {
+		feedURL, ok := sectionFeedURLs[section]
+		if !ok {
+			continue
 		}
 
-		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			findArticleBodyNode(c)
-		}
-	}
-	findArticleBodyNode(doc)
-
-	// Given the article body node, extract the text
-	var f func(*html.Node)
-	f = func(n *html.Node) {
-		if n.Type == html.TextNode && n.Parent.Data == "p" {
-			paragraph = paragraph + n.Data + "\n"
+		items, err := a.feedSource.FetchItems(feedURL)
+		if err != nil {
+			return err
 		}
 
-		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			f(c)
+		for _, item := range items {
+			articles = append(articles, Article{
+				URL:         item.URL,
+				Title:       item.Title,
+				Section:     section,
+				DateCreated: item.DateCreated,
+			})
 		}
-
 	}
 
-	// NYTimes loves interactive articles (and they are amazing!). Unfortunately, it is not
-	// the usual text format and therefore cannot be extract
-	// (for example: https://www.nytimes.com/interactive/2020/obituaries/people-died-coronavirus-obituaries.html#lloyd-porter).
-	// Most likely they don't have an article node, and we will skip those interactive ones.
-	if articleBodyNode != nil {
-		f(articleBodyNode)
-	}
+	a.TopStories.Articles = articles
+	return nil
+}
 
-	return paragraph, nil
+// seenKey is the SeenStore key for article: its URL plus published date,
+// so a republished article (same URL, new date) isn't treated as seen.
+func seenKey(article *Article) string {
+	return article.URL + "|" + article.DateCreated
 }
 
-func GenerateArticleText(article *Article) {
-	defer wg.Done()
+// GenerateArticleText extracts article.URL's body text and tags, returning
+// the first extraction error encountered instead of silently leaving the
+// article blank. It does not mark the article as seen: GenerateArticles
+// only does that once the article has also cleared the publish step, so a
+// Publish failure can still be retried on the next poll.
+func GenerateArticleText(ctx context.Context, article *Article) error {
+	text, err := extractor.ExtractText(ctx, article.URL)
+	if err != nil {
+		return err
+	}
 
-	text, _ := ExtractText(article.URL)
 	if text != "" {
 		article.Text = text
 
@@ -198,44 +274,89 @@ func GenerateArticleText(article *Article) {
 			article.Tags = tags
 		}
 	}
+
+	return nil
 }
 
 //	Construct the Article list (TopStories struct).
 //	Each Article in the list will only contain the URL, Section, and Title
 //	after this call. These are the value returned from NYTimes API.
-func (a *API) GenerateArticles() error {
-	if a.url == "" {
-		a.generateURL()
-	}
+//	ctx bounds the whole call, including every article's extraction; cancel
+//	it to stop in-flight HTTP requests early.
+func (a *API) GenerateArticles(ctx context.Context) error {
+	var err error
+
+	if a.sourceType == SourceRSS {
+		if a.feedSource == nil {
+			a.feedSource = feed.NewRSSSource()
+		}
 
-	resp, err := http.Get(a.url)
+		if err = a.fetchFromFeeds(); err != nil {
+			return err
+		}
+	} else {
+		if a.url == "" {
+			a.generateURL()
+		}
 
-	if err != nil {
-		return err
-	}
-	defer func() {
-		err = resp.Body.Close()
-	}()
+		resp, respErr := http.Get(a.url)
+		if respErr != nil {
+			return respErr
+		}
 
-	bytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
+		// Close is not deferred: a deferred assignment into the outer err
+		// here would be overwritten long before this function's final
+		// return, since err isn't a named return value. Check it eagerly
+		// instead of silently dropping it.
+		bodyBytes, readErr := ioutil.ReadAll(resp.Body)
+		closeErr := resp.Body.Close()
+		if readErr != nil {
+			return readErr
+		}
+		if closeErr != nil {
+			return closeErr
+		}
+
+		if unmarshalErr := json.Unmarshal(bodyBytes, &a.TopStories); unmarshalErr != nil {
+			return unmarshalErr
+		}
+
+		a.FilterBySections()
 	}
 
-	err = json.Unmarshal(bytes, &a.TopStories)
-	if err != nil {
-		return err
+	// Skip articles we've already processed on a previous run, so a
+	// long-running poller doesn't re-fetch and re-extract every story
+	// every time it polls.
+	if a.seenStore != nil {
+		var pending []Article
+		for i := range a.TopStories.Articles {
+			seen, seenErr := a.seenStore.Seen(seenKey(&a.TopStories.Articles[i]))
+			if seenErr == nil && seen {
+				continue
+			}
+			pending = append(pending, a.TopStories.Articles[i])
+		}
+		a.TopStories.Articles = pending
 	}
 
-	a.FilterBySections()
+	// Extract text from URL, bounded to a.concurrency concurrent requests.
+	// The first extraction error cancels gctx, stopping the rest early,
+	// and is returned instead of being silently dropped. a.selectors rides
+	// along on ctx so the nytimes extractor's per-section overrides stay
+	// scoped to this API instance.
+	g, gctx := errgroup.WithContext(nytimesextractor.WithSelectors(ctx, a.selectors))
+	g.SetLimit(a.concurrency)
 
-	// Extract text from URL
 	for i := range a.TopStories.Articles {
-		wg.Add(1)
-		go GenerateArticleText(&a.TopStories.Articles[i])
+		i := i
+		g.Go(func() error {
+			return GenerateArticleText(gctx, &a.TopStories.Articles[i])
+		})
 	}
 
-	wg.Wait()
+	if err := g.Wait(); err != nil {
+		return err
+	}
 
 	// Filter out the node that is interactive ~= article.text == ""
 	var articleWithText []Article
@@ -247,6 +368,30 @@ func (a *API) GenerateArticles() error {
 
 	a.TopStories.Articles = articleWithText
 
+	// Mark-seen happens per-article only once it's cleared publishing (or
+	// immediately, if there's no publisher to clear), so a Publish failure
+	// for one article - Mastodon down, rate-limited, a network blip -
+	// leaves that article unmarked and eligible for retry on the next
+	// poll, instead of being silently lost to the seen store forever.
+	for i := range a.TopStories.Articles {
+		article := &a.TopStories.Articles[i]
+
+		if a.publisher != nil {
+			if pubErr := a.publisher.Publish(article); pubErr != nil {
+				if err == nil {
+					err = pubErr
+				}
+				continue
+			}
+		}
+
+		if a.seenStore != nil {
+			if seenErr := a.seenStore.MarkSeen(seenKey(article)); seenErr != nil && err == nil {
+				err = seenErr
+			}
+		}
+	}
+
 	return err
 }
 
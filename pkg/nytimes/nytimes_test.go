@@ -0,0 +1,97 @@
+package nytimes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/vitsensei/infogrid/pkg/feed"
+	"github.com/vitsensei/infogrid/pkg/models"
+	"github.com/vitsensei/infogrid/pkg/seenstore"
+)
+
+func TestSeenKey(t *testing.T) {
+	a := &Article{URL: "https://nytimes.com/a.html", DateCreated: "2024-01-01T00:00:00Z"}
+	b := &Article{URL: "https://nytimes.com/a.html", DateCreated: "2024-02-01T00:00:00Z"}
+
+	if seenKey(a) == seenKey(b) {
+		t.Fatalf("seenKey should differ when DateCreated differs, got %q for both", seenKey(a))
+	}
+
+	if got, want := seenKey(a), a.URL+"|"+a.DateCreated; got != want {
+		t.Errorf("seenKey() = %q, want %q", got, want)
+	}
+}
+
+func TestFilterBySections(t *testing.T) {
+	api := NewAPI()
+	api.TopStories.Articles = []Article{
+		{URL: "https://nytimes.com/1.html", Section: "business"},
+		{URL: "https://nytimes.com/2.html", Section: "obituaries"},
+		{URL: "https://nytimes.com/3.html", Section: "world"},
+	}
+
+	api.FilterBySections()
+
+	if len(api.TopStories.Articles) != 2 {
+		t.Fatalf("FilterBySections() left %d articles, want 2: %+v", len(api.TopStories.Articles), api.TopStories.Articles)
+	}
+	for _, article := range api.TopStories.Articles {
+		if article.Section == "obituaries" {
+			t.Errorf("FilterBySections() kept disallowed section %q", article.Section)
+		}
+	}
+}
+
+// fakeFeedSource hands back a single item pointing at whatever URL the test
+// wires up, regardless of the feed URL requested.
+type fakeFeedSource struct {
+	item feed.Item
+}
+
+func (f fakeFeedSource) FetchItems(string) ([]feed.Item, error) {
+	return []feed.Item{f.item}, nil
+}
+
+// failingPublisher always fails, to simulate Mastodon being down/rate-limited.
+type failingPublisher struct{}
+
+func (failingPublisher) Publish(article models.ArticleInterface) error {
+	return errors.New("publish failed")
+}
+
+func TestGenerateArticlesDoesNotMarkSeenOnPublishFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html><body><article><p>Body text.</p></article></body></html>`))
+	}))
+	defer server.Close()
+
+	seenStore := seenstore.NewMemoryStore()
+
+	api := NewAPI()
+	api.sourceType = SourceRSS
+	api.feedSource = fakeFeedSource{item: feed.Item{
+		URL:         server.URL,
+		Title:       "Test article",
+		DateCreated: "2024-01-01T00:00:00Z",
+	}}
+	api.allowedSections = []string{"business"}
+	api.seenStore = seenStore
+	api.publisher = failingPublisher{}
+
+	err := api.GenerateArticles(context.Background())
+	if err == nil {
+		t.Fatal("GenerateArticles() error = nil, want the publish failure surfaced")
+	}
+
+	key := server.URL + "|2024-01-01T00:00:00Z"
+	seen, seenErr := seenStore.Seen(key)
+	if seenErr != nil {
+		t.Fatalf("seenStore.Seen() error = %v", seenErr)
+	}
+	if seen {
+		t.Fatal("article was marked seen despite its Publish call failing; it will never be retried")
+	}
+}
@@ -0,0 +1,25 @@
+package mastodon
+
+import (
+	"context"
+
+	"github.com/mattn/go-mastodon"
+)
+
+// RegisterApp registers a new OAuth application on server and returns its
+// client ID/secret. Run this once per instance and persist the result
+// alongside Config.ClientID/ClientSecret; there's no need to re-register on
+// every startup.
+func RegisterApp(ctx context.Context, server, clientName, website string) (clientID, clientSecret string, err error) {
+	app, err := mastodon.RegisterApp(ctx, &mastodon.AppConfig{
+		Server:     server,
+		ClientName: clientName,
+		Scopes:     "write:statuses",
+		Website:    website,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return app.ClientID, app.ClientSecret, nil
+}
@@ -0,0 +1,42 @@
+package mastodon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegisterApp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/apps" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"1","client_id":"the-client-id","client_secret":"the-client-secret"}`))
+	}))
+	defer server.Close()
+
+	clientID, clientSecret, err := RegisterApp(context.Background(), server.URL, "infogrid", "https://example.com")
+	if err != nil {
+		t.Fatalf("RegisterApp() error = %v", err)
+	}
+	if clientID != "the-client-id" {
+		t.Errorf("clientID = %q, want %q", clientID, "the-client-id")
+	}
+	if clientSecret != "the-client-secret" {
+		t.Errorf("clientSecret = %q, want %q", clientSecret, "the-client-secret")
+	}
+}
+
+func TestRegisterAppError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	_, _, err := RegisterApp(context.Background(), server.URL, "infogrid", "https://example.com")
+	if err == nil {
+		t.Fatal("RegisterApp() error = nil, want an error for a 500 response")
+	}
+}
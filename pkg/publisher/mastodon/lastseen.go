@@ -0,0 +1,76 @@
+package mastodon
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+)
+
+// lastSeenStore tracks which article URLs have already been posted, so a
+// process that's restarted (cron/systemd timer) doesn't double-toot. It's
+// intentionally simpler than a full dedup index: just a newline-delimited
+// file of seen URLs.
+type lastSeenStore struct {
+	path string
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func newLastSeenStore(path string) (*lastSeenStore, error) {
+	s := &lastSeenStore{
+		path: path,
+		seen: map[string]bool{},
+	}
+
+	if path == "" {
+		return s, nil
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	for _, line := range strings.Split(string(bytes), "\n") {
+		if line != "" {
+			s.seen[line] = true
+		}
+	}
+
+	return s, nil
+}
+
+func (s *lastSeenStore) Has(url string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.seen[url]
+}
+
+// Add records url as seen and appends it to the backing file, if any.
+func (s *lastSeenStore) Add(url string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen[url] {
+		return nil
+	}
+	s.seen[url] = true
+
+	if s.path == "" {
+		return nil
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(url + "\n")
+	return err
+}
@@ -0,0 +1,71 @@
+package mastodon
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLastSeenStoreInMemoryWithoutPath(t *testing.T) {
+	s, err := newLastSeenStore("")
+	if err != nil {
+		t.Fatalf("newLastSeenStore() error = %v", err)
+	}
+
+	if s.Has("https://example.com/a") {
+		t.Fatal("Has() = true for a URL never added")
+	}
+
+	if err := s.Add("https://example.com/a"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if !s.Has("https://example.com/a") {
+		t.Fatal("Has() = false right after Add()")
+	}
+}
+
+func TestLastSeenStorePersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.txt")
+
+	s1, err := newLastSeenStore(path)
+	if err != nil {
+		t.Fatalf("newLastSeenStore() error = %v", err)
+	}
+	if err := s1.Add("https://example.com/a"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	s2, err := newLastSeenStore(path)
+	if err != nil {
+		t.Fatalf("newLastSeenStore() error = %v", err)
+	}
+	if !s2.Has("https://example.com/a") {
+		t.Fatal("a freshly-loaded store doesn't see a URL added by a prior instance")
+	}
+	if s2.Has("https://example.com/b") {
+		t.Fatal("Has() = true for a URL never added")
+	}
+}
+
+func TestLastSeenStoreAddIsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "seen.txt")
+
+	s, err := newLastSeenStore(path)
+	if err != nil {
+		t.Fatalf("newLastSeenStore() error = %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := s.Add("https://example.com/a"); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+
+	reloaded, err := newLastSeenStore(path)
+	if err != nil {
+		t.Fatalf("newLastSeenStore() error = %v", err)
+	}
+	if !reloaded.Has("https://example.com/a") {
+		t.Fatal("reloaded store doesn't see the URL added twice")
+	}
+}
@@ -0,0 +1,116 @@
+// Package mastodon publishes newly extracted articles to a
+// Mastodon-compatible instance, so infogrid can run as a news bot: fetch
+// NYTimes top stories -> extract -> summarise -> toot.
+package mastodon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mattn/go-mastodon"
+	"github.com/vitsensei/infogrid/pkg/models"
+)
+
+// Config holds the credentials and posting preferences for a Publisher.
+// ClientID/ClientSecret come from RegisterApp; AccessToken is obtained
+// separately via the usual OAuth user-auth flow.
+type Config struct {
+	Server       string
+	ClientID     string
+	ClientSecret string
+	AccessToken  string
+
+	// Visibility is one of "public", "unlisted", "private", "direct".
+	// Defaults to "public" when empty.
+	Visibility string
+
+	// MinInterval is the minimum time between two posts. Defaults to 30s
+	// when zero, which keeps a single instance well under most Mastodon
+	// rate limits (300 requests / 5 minutes).
+	MinInterval time.Duration
+
+	// LastSeenPath, if set, persists posted article URLs to this file so
+	// a restarted process doesn't re-toot them.
+	LastSeenPath string
+}
+
+// Publisher posts ArticleInterface values as Mastodon statuses.
+type Publisher struct {
+	client      *mastodon.Client
+	visibility  string
+	minInterval time.Duration
+	lastPosted  time.Time
+	seen        *lastSeenStore
+}
+
+// NewPublisher builds a Publisher from cfg, loading its last-seen-URL store
+// from disk if cfg.LastSeenPath is set.
+func NewPublisher(cfg Config) (*Publisher, error) {
+	visibility := cfg.Visibility
+	if visibility == "" {
+		visibility = "public"
+	}
+
+	minInterval := cfg.MinInterval
+	if minInterval == 0 {
+		minInterval = 30 * time.Second
+	}
+
+	seen, err := newLastSeenStore(cfg.LastSeenPath)
+	if err != nil {
+		return nil, err
+	}
+
+	client := mastodon.NewClient(&mastodon.Config{
+		Server:       cfg.Server,
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret,
+		AccessToken:  cfg.AccessToken,
+	})
+
+	return &Publisher{
+		client:      client,
+		visibility:  visibility,
+		minInterval: minInterval,
+		seen:        seen,
+	}, nil
+}
+
+// Publish toots article, skipping it if its URL has already been posted.
+// Callers are expected to invoke this sequentially per Publisher (it's not
+// meant to be called concurrently from multiple goroutines), which is what
+// lets the rate limiting below be a simple sleep.
+func (p *Publisher) Publish(article models.ArticleInterface) error {
+	if p.seen.Has(article.GetURL()) {
+		return nil
+	}
+
+	if wait := p.minInterval - time.Since(p.lastPosted); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	status := formatStatus(article)
+
+	_, err := p.client.PostStatus(context.Background(), &mastodon.Toot{
+		Status:     status,
+		Visibility: p.visibility,
+	})
+	p.lastPosted = time.Now()
+	if err != nil {
+		return err
+	}
+
+	return p.seen.Add(article.GetURL())
+}
+
+// formatStatus builds the toot body from an article's title, URL and tags.
+func formatStatus(article models.ArticleInterface) string {
+	status := fmt.Sprintf("%s\n%s", article.GetTitle(), article.GetURL())
+
+	for _, tag := range article.GetTags() {
+		status += fmt.Sprintf(" #%s", tag)
+	}
+
+	return status
+}
@@ -0,0 +1,55 @@
+package seenstore
+
+import (
+	"go.etcd.io/bbolt"
+)
+
+var seenBucket = []byte("seen")
+
+// BoltStore is a SeenStore backed by a BoltDB file, so dedup survives
+// across process restarts.
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+// NewBoltStore opens (creating if needed) a BoltDB file at path for use as
+// a SeenStore.
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(seenBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (b *BoltStore) Seen(key string) (bool, error) {
+	var seen bool
+
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		seen = tx.Bucket(seenBucket).Get([]byte(key)) != nil
+		return nil
+	})
+
+	return seen, err
+}
+
+func (b *BoltStore) MarkSeen(key string) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(seenBucket).Put([]byte(key), []byte{1})
+	})
+}
+
+// Close releases the underlying BoltDB file handle.
+func (b *BoltStore) Close() error {
+	return b.db.Close()
+}
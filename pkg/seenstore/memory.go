@@ -0,0 +1,32 @@
+package seenstore
+
+import "sync"
+
+// MemoryStore is a SeenStore backed by a map, with no persistence across
+// restarts. Useful for tests and for one-off runs where dedup only needs
+// to hold for the lifetime of the process.
+type MemoryStore struct {
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		seen: map[string]bool{},
+	}
+}
+
+func (m *MemoryStore) Seen(key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.seen[key], nil
+}
+
+func (m *MemoryStore) MarkSeen(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.seen[key] = true
+	return nil
+}
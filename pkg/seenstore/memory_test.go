@@ -0,0 +1,35 @@
+package seenstore
+
+import "testing"
+
+func TestMemoryStoreSeen(t *testing.T) {
+	store := NewMemoryStore()
+
+	seen, err := store.Seen("https://example.com/a|2024-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+	if seen {
+		t.Fatalf("Seen() = true for a key never marked, want false")
+	}
+
+	if err := store.MarkSeen("https://example.com/a|2024-01-01T00:00:00Z"); err != nil {
+		t.Fatalf("MarkSeen() error = %v", err)
+	}
+
+	seen, err = store.Seen("https://example.com/a|2024-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+	if !seen {
+		t.Fatalf("Seen() = false after MarkSeen, want true")
+	}
+
+	seen, err = store.Seen("https://example.com/b|2024-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("Seen() error = %v", err)
+	}
+	if seen {
+		t.Fatalf("Seen() = true for an unrelated key, want false")
+	}
+}
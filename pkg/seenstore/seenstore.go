@@ -0,0 +1,11 @@
+// Package seenstore tracks which articles have already been processed, so
+// a long-running poller (cron/systemd timer) doesn't re-fetch and
+// re-extract the same stories on every run.
+package seenstore
+
+// SeenStore records and queries whether a given key (typically an
+// article's URL plus its published date) has already been processed.
+type SeenStore interface {
+	Seen(key string) (bool, error)
+	MarkSeen(key string) error
+}